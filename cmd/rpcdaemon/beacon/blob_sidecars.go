@@ -0,0 +1,222 @@
+// Package beacon serves a small slice of the beacon-node HTTP API directly
+// off the execution-layer database, so that tooling built against
+// /eth/v1/beacon endpoints can talk to the RPC daemon without a separate
+// consensus client.
+package beacon
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+)
+
+const (
+	contentTypeJSON = "application/json"
+	contentTypeSSZ  = "application/octet-stream"
+)
+
+// BlobSidecar is the (versioned_hash, kzg_commitment, kzg_proof, blob) tuple
+// stored for every blob in a Cancun block, keyed by block hash and blob
+// index.
+type BlobSidecar = rawdb.BlobSidecar
+
+// ErrBlockNotFound is returned by a BlobStore when the requested block is
+// unknown, has no stored sidecars (e.g. it predates Cancun), or has been
+// pruned.
+var ErrBlockNotFound = errors.New("block not found")
+
+// BlobStore serves stored blob sidecars back by block hash. It has no write
+// method; sidecars are stored through core/rawdb.WriteBlobSidecars.
+type BlobStore interface {
+	// BlobSidecars returns every sidecar stored for blockHash, or
+	// ErrBlockNotFound if the block is unknown or has been pruned.
+	BlobSidecars(blockHash common.Hash) ([]BlobSidecar, error)
+
+	// ResolveBlockID resolves a block_id path parameter ("head", "finalized",
+	// "genesis", a decimal slot, or a 0x-prefixed block root) to a block
+	// hash, or ErrBlockNotFound if it cannot be resolved.
+	ResolveBlockID(blockID string) (common.Hash, error)
+}
+
+// Handler serves GET /eth/v1/beacon/blob_sidecars/{block_id}.
+type Handler struct {
+	Store BlobStore
+}
+
+// NewHandler returns an http.Handler backed by store.
+func NewHandler(store BlobStore) *Handler {
+	return &Handler{Store: store}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	blockID := strings.TrimPrefix(r.URL.Path, "/eth/v1/beacon/blob_sidecars/")
+	if blockID == "" || blockID == r.URL.Path {
+		writeError(w, http.StatusNotFound, "block_id is required")
+		return
+	}
+
+	blockHash, err := h.Store.ResolveBlockID(blockID)
+	if err != nil {
+		if errors.Is(err, ErrSlotResolutionUnsupported) {
+			writeError(w, http.StatusNotImplemented, err.Error())
+			return
+		}
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	sidecars, err := h.Store.BlobSidecars(blockHash)
+	if err != nil {
+		if errors.Is(err, ErrBlockNotFound) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if indices, ok, err := parseIndices(r.URL.Query()["indices"]); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if ok {
+		sidecars = filterIndices(sidecars, indices)
+	}
+
+	contentType, ok := negotiateContentType(r.Header.Get("Accept"))
+	if !ok {
+		writeError(w, http.StatusNotAcceptable, "only application/json and application/octet-stream are supported")
+		return
+	}
+
+	if contentType == contentTypeSSZ {
+		w.Header().Set("Content-Type", contentTypeSSZ)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(encodeSidecarsSSZ(sidecars))
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(struct {
+		Data []BlobSidecar `json:"data"`
+	}{Data: sidecars})
+}
+
+// parseIndices accepts either a single comma-separated "indices" query value
+// or several repeated ones, matching the beacon API's convention for
+// repeatable query parameters.
+func parseIndices(raw []string) ([]uint64, bool, error) {
+	if len(raw) == 0 {
+		return nil, false, nil
+	}
+	var indices []uint64
+	for _, group := range raw {
+		for _, s := range strings.Split(group, ",") {
+			if s == "" {
+				continue
+			}
+			idx, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				return nil, false, errors.New("invalid indices: " + s)
+			}
+			indices = append(indices, idx)
+		}
+	}
+	return indices, true, nil
+}
+
+func filterIndices(sidecars []BlobSidecar, indices []uint64) []BlobSidecar {
+	want := make(map[uint64]bool, len(indices))
+	for _, idx := range indices {
+		want[idx] = true
+	}
+	filtered := sidecars[:0]
+	for _, sidecar := range sidecars {
+		if want[sidecar.Index] {
+			filtered = append(filtered, sidecar)
+		}
+	}
+	return filtered
+}
+
+// negotiateContentType picks the response media type named in accept,
+// defaulting to JSON whenever the header is absent or accepts any type, to
+// match the rest of the execution-layer HTTP API. It reports false if
+// accept names only media types this handler can't produce.
+func negotiateContentType(accept string) (string, bool) {
+	if accept == "" {
+		return contentTypeJSON, true
+	}
+	for _, part := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		switch mediaType {
+		case "*/*", contentTypeJSON:
+			return contentTypeJSON, true
+		case contentTypeSSZ:
+			return contentTypeSSZ, true
+		}
+	}
+	return "", false
+}
+
+// sszSidecarFixedSize is the size of a BlobSidecar's fixed-size SSZ fields:
+// an 8-byte Index, a 4-byte offset standing in for the variable-size Blob,
+// and three 32-byte hashes.
+const sszSidecarFixedSize = 8 + 4 + 32 + 32 + 32
+
+// encodeSidecarSSZ encodes a single BlobSidecar as an SSZ container: the
+// fixed-size fields (Index, an offset to Blob, then the three hashes) in
+// field order, followed by the variable-size Blob bytes.
+func encodeSidecarSSZ(s BlobSidecar) []byte {
+	buf := make([]byte, sszSidecarFixedSize+len(s.Blob))
+	binary.LittleEndian.PutUint64(buf[0:8], s.Index)
+	binary.LittleEndian.PutUint32(buf[8:12], uint32(sszSidecarFixedSize))
+	copy(buf[12:44], s.KZGCommitment[:])
+	copy(buf[44:76], s.KZGProof[:])
+	copy(buf[76:108], s.VersionedHash[:])
+	copy(buf[108:], s.Blob)
+	return buf
+}
+
+// encodeSidecarsSSZ encodes sidecars as an SSZ list of variable-size
+// BlobSidecar containers: a 4-byte offset per element, each pointing into
+// the element data that follows the offset table in order.
+func encodeSidecarsSSZ(sidecars []BlobSidecar) []byte {
+	elements := make([][]byte, len(sidecars))
+	for i, s := range sidecars {
+		elements[i] = encodeSidecarSSZ(s)
+	}
+
+	offsetTableSize := 4 * len(elements)
+	total := offsetTableSize
+	for _, e := range elements {
+		total += len(e)
+	}
+
+	buf := make([]byte, total)
+	offset := uint32(offsetTableSize)
+	for i, e := range elements {
+		binary.LittleEndian.PutUint32(buf[4*i:4*i+4], offset)
+		offset += uint32(len(e))
+	}
+	pos := offsetTableSize
+	for _, e := range elements {
+		pos += copy(buf[pos:], e)
+	}
+	return buf
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{Code: code, Message: message})
+}
@@ -0,0 +1,160 @@
+package beacon
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ledgerwatch/erigon/common"
+)
+
+type fakeStore struct {
+	byHash map[common.Hash][]BlobSidecar
+	head   common.Hash
+}
+
+func (f *fakeStore) BlobSidecars(blockHash common.Hash) ([]BlobSidecar, error) {
+	sidecars, ok := f.byHash[blockHash]
+	if !ok {
+		return nil, ErrBlockNotFound
+	}
+	return sidecars, nil
+}
+
+func (f *fakeStore) ResolveBlockID(blockID string) (common.Hash, error) {
+	if blockID == "head" {
+		return f.head, nil
+	}
+	if h := common.HexToHash(blockID); blockID == h.Hex() {
+		return h, nil
+	}
+	return common.Hash{}, ErrBlockNotFound
+}
+
+func TestHandlerHeadAllIndices(t *testing.T) {
+	head := common.HexToHash("0x01")
+	store := &fakeStore{head: head, byHash: map[common.Hash][]BlobSidecar{
+		head: {{Index: 0}, {Index: 1}},
+	}}
+	h := NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodGet, "/eth/v1/beacon/blob_sidecars/head", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+}
+
+func TestHandlerFiltersIndices(t *testing.T) {
+	head := common.HexToHash("0x01")
+	store := &fakeStore{head: head, byHash: map[common.Hash][]BlobSidecar{
+		head: {{Index: 0}, {Index: 1}, {Index: 2}},
+	}}
+	h := NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodGet, "/eth/v1/beacon/blob_sidecars/head?indices=1,2", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+
+	var body struct {
+		Data []BlobSidecar `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decoding response body: %v", err)
+	}
+	if len(body.Data) != 2 {
+		t.Fatalf("expected 2 sidecars, got %d: %+v", len(body.Data), body.Data)
+	}
+	for _, sidecar := range body.Data {
+		if sidecar.Index != 1 && sidecar.Index != 2 {
+			t.Fatalf("unexpected sidecar index %d in filtered response", sidecar.Index)
+		}
+	}
+}
+
+func TestHandlerSSZ(t *testing.T) {
+	head := common.HexToHash("0x01")
+	sidecar := BlobSidecar{Index: 1, Blob: []byte{0xaa, 0xbb, 0xcc}, KZGCommitment: common.HexToHash("0x02")}
+	store := &fakeStore{head: head, byHash: map[common.Hash][]BlobSidecar{
+		head: {sidecar},
+	}}
+	h := NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodGet, "/eth/v1/beacon/blob_sidecars/head", nil)
+	req.Header.Set("Accept", "application/octet-stream")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "application/octet-stream" {
+		t.Fatalf("expected octet-stream content-type, got %q", ct)
+	}
+
+	body := rr.Body.Bytes()
+	wantElementSize := sszSidecarFixedSize + len(sidecar.Blob)
+	if len(body) != 4+wantElementSize {
+		t.Fatalf("expected a 4-byte offset table plus a %d-byte element, got %d bytes", wantElementSize, len(body))
+	}
+	offset := binary.LittleEndian.Uint32(body[0:4])
+	if offset != 4 {
+		t.Fatalf("expected element offset 4, got %d", offset)
+	}
+	gotIndex := binary.LittleEndian.Uint64(body[4:12])
+	if gotIndex != sidecar.Index {
+		t.Fatalf("expected encoded index %d, got %d", sidecar.Index, gotIndex)
+	}
+}
+
+func TestHandlerRejectsUnknownAccept(t *testing.T) {
+	head := common.HexToHash("0x01")
+	store := &fakeStore{head: head, byHash: map[common.Hash][]BlobSidecar{head: {{Index: 0}}}}
+	h := NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodGet, "/eth/v1/beacon/blob_sidecars/head", nil)
+	req.Header.Set("Accept", "text/plain")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotAcceptable {
+		t.Fatalf("expected 406, got %d", rr.Code)
+	}
+}
+
+func TestHandlerSlotBlockIDReturns501(t *testing.T) {
+	store := NewKVBlobStore(nil, nil)
+	h := NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodGet, "/eth/v1/beacon/blob_sidecars/12345", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotImplemented {
+		t.Fatalf("expected 501, got %d", rr.Code)
+	}
+}
+
+func TestHandlerPrunedBlockReturns404(t *testing.T) {
+	store := &fakeStore{head: common.HexToHash("0x02"), byHash: map[common.Hash][]BlobSidecar{}}
+	h := NewHandler(store)
+
+	req := httptest.NewRequest(http.MethodGet, "/eth/v1/beacon/blob_sidecars/head", nil)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rr.Code)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("expected json error body, got content-type %q", ct)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package beacon
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/ledgerwatch/erigon-lib/kv"
+
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+)
+
+// ErrSlotResolutionUnsupported is returned by KVBlobStore.ResolveBlockID for
+// a decimal slot block_id: slot-to-block-hash resolution requires a
+// consensus-client-backed index that this execution-layer-only store
+// doesn't have. Handlers should report this distinctly from
+// ErrBlockNotFound so callers can tell "not implemented" apart from "block
+// doesn't exist".
+var ErrSlotResolutionUnsupported = errors.New("resolving block_id by slot is not supported")
+
+// HeadResolver resolves the beacon API's named block identifiers ("head",
+// "finalized", "genesis") to execution-layer block hashes. The RPC daemon's
+// existing chain-head tracking backs this; it is kept as an interface here
+// so KVBlobStore doesn't have to depend on the rest of the daemon.
+type HeadResolver interface {
+	HeadHash() (common.Hash, error)
+	FinalizedHash() (common.Hash, error)
+	GenesisHash() (common.Hash, error)
+}
+
+// KVBlobStore is the BlobStore backed by the node's existing kv database.
+// Sidecars are written by core/rawdb.WriteBlobSidecars (or
+// MaybeWriteBlobSidecars), which block import does not call, and read back
+// here for the HTTP handler.
+type KVBlobStore struct {
+	DB   kv.RoDB
+	Head HeadResolver
+}
+
+// NewKVBlobStore returns a BlobStore that reads sidecars out of db and
+// resolves named block_ids via head.
+func NewKVBlobStore(db kv.RoDB, head HeadResolver) *KVBlobStore {
+	return &KVBlobStore{DB: db, Head: head}
+}
+
+func (s *KVBlobStore) BlobSidecars(blockHash common.Hash) ([]BlobSidecar, error) {
+	var sidecars []BlobSidecar
+	err := s.DB.View(context.Background(), func(tx kv.Tx) error {
+		var err error
+		sidecars, err = rawdb.ReadBlobSidecars(tx, blockHash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(sidecars) == 0 {
+		return nil, ErrBlockNotFound
+	}
+	return sidecars, nil
+}
+
+func (s *KVBlobStore) ResolveBlockID(blockID string) (common.Hash, error) {
+	switch blockID {
+	case "head":
+		return s.Head.HeadHash()
+	case "finalized":
+		return s.Head.FinalizedHash()
+	case "genesis":
+		return s.Head.GenesisHash()
+	}
+	if strings.HasPrefix(blockID, "0x") {
+		return common.HexToHash(blockID), nil
+	}
+	if _, err := strconv.ParseUint(blockID, 10, 64); err == nil {
+		return common.Hash{}, ErrSlotResolutionUnsupported
+	}
+	return common.Hash{}, ErrBlockNotFound
+}
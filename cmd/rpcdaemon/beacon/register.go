@@ -0,0 +1,31 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package beacon
+
+import "net/http"
+
+// blobSidecarsPrefix is the path prefix routed to the blob sidecar handler.
+// ServeHTTP strips it back off to recover the block_id path parameter.
+const blobSidecarsPrefix = "/eth/v1/beacon/blob_sidecars/"
+
+// RegisterRoutes wires the beacon-style blob sidecar endpoint into mux,
+// alongside the daemon's JSON-RPC handler. Call it once from the RPC
+// daemon's HTTP server setup, after constructing a BlobStore (typically a
+// *KVBlobStore) for the node's database.
+func RegisterRoutes(mux *http.ServeMux, store BlobStore) {
+	mux.Handle(blobSidecarsPrefix, NewHandler(store))
+}
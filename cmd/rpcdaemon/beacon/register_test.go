@@ -0,0 +1,36 @@
+package beacon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ledgerwatch/erigon/common"
+)
+
+// TestRegisterRoutesServesBlobSidecars exercises RegisterRoutes the way
+// ListenAndServe (and, eventually, the daemon's own HTTP setup) calls it: by
+// registering it on a real mux and driving a request through net/http
+// end-to-end, rather than calling the Handler directly.
+func TestRegisterRoutesServesBlobSidecars(t *testing.T) {
+	head := common.HexToHash("0x01")
+	store := &fakeStore{head: head, byHash: map[common.Hash][]BlobSidecar{
+		head: {{Index: 0}},
+	}}
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux, store)
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/eth/v1/beacon/blob_sidecars/head")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package beacon
+
+import "net/http"
+
+// ListenAndServe starts a standalone HTTP server exposing the beacon-style
+// blob sidecar endpoint backed by store, listening on addr. It exists so
+// this package is runnable on its own; a daemon that already has an
+// http.ServeMux for JSON-RPC should call RegisterRoutes on that mux instead
+// of starting a second listener.
+func ListenAndServe(addr string, store BlobStore) error {
+	mux := http.NewServeMux()
+	RegisterRoutes(mux, store)
+	return http.ListenAndServe(addr, mux)
+}
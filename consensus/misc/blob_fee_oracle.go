@@ -0,0 +1,145 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package misc
+
+import (
+	"container/list"
+	"math/big"
+	"sync"
+
+	"github.com/holiman/uint256"
+
+	"github.com/ledgerwatch/erigon/params"
+)
+
+// blobFeeOracleCacheSize bounds the number of distinct excessBlobGas values
+// the oracle's LRU remembers beyond the precomputed dense table.
+const blobFeeOracleCacheSize = 4096
+
+// blobFeeOracleTableMultiple bounds the dense table to
+// [0, blobFeeOracleTableMultiple*TargetDataGasPerBlock], the range excessBlobGas
+// stays within under ordinary blob-gas demand.
+const blobFeeOracleTableMultiple = 16
+
+// BlobFeeOracle memoizes GetBlobGasPrice so that the hot txpool-admission and
+// block-templating paths, which call it on every blob tx and every block,
+// don't re-run FakeExponential's Taylor expansion on every call. Values on
+// the multiples of DataGasPerBlob in [0, 16*TargetDataGasPerBlock] are
+// precomputed once at construction; everything else falls back to a small
+// LRU of the most recently seen excessBlobGas values.
+type BlobFeeOracle struct {
+	table []*uint256.Int // table[i] is the price for excessBlobGas == i*DataGasPerBlob
+
+	mu    sync.Mutex
+	cache map[uint64]*list.Element // keyed by excessBlobGas.Uint64(), verified against full value on hit
+	lru   *list.List
+}
+
+type blobFeeOracleEntry struct {
+	excess *big.Int
+	price  *uint256.Int
+}
+
+// NewBlobFeeOracle constructs a BlobFeeOracle, precomputing the dense table
+// for the common excessBlobGas range.
+func NewBlobFeeOracle() (*BlobFeeOracle, error) {
+	tableLen := blobFeeOracleTableMultiple*params.TargetDataGasPerBlock/params.DataGasPerBlob + 1
+	o := &BlobFeeOracle{
+		table: make([]*uint256.Int, tableLen),
+		cache: make(map[uint64]*list.Element),
+		lru:   list.New(),
+	}
+	for i := range o.table {
+		excess := new(big.Int).Mul(big.NewInt(int64(i)), big.NewInt(params.DataGasPerBlob))
+		price, err := calcBlobGasPrice(excess)
+		if err != nil {
+			return nil, err
+		}
+		o.table[i] = price
+	}
+	return o, nil
+}
+
+var (
+	defaultOracle     *BlobFeeOracle
+	defaultOracleOnce sync.Once
+)
+
+// DefaultBlobFeeOracle returns the package-wide BlobFeeOracle that
+// GetBlobGasPrice serves from, building it lazily on first use. Callers on
+// the hot admission/templating paths that already hold an excessBlobGas and
+// just want a price should call GetBlobGasPrice instead; this is exposed for
+// callers (and tests) that need the oracle itself, e.g. to confirm a given
+// code path is actually served from it rather than from a fresh
+// FakeExponential computation.
+func DefaultBlobFeeOracle() *BlobFeeOracle {
+	defaultOracleOnce.Do(func() {
+		oracle, err := NewBlobFeeOracle()
+		if err != nil {
+			// The dense table only fails to build if FakeExponential
+			// overflows within [0, 16*TargetDataGasPerBlock], which would
+			// mean the chain's own params are inconsistent.
+			panic(err)
+		}
+		defaultOracle = oracle
+	})
+	return defaultOracle
+}
+
+// Price returns the wei-per-blob-gas price for the given excessBlobGas,
+// serving it from the dense table or LRU cache when possible and falling
+// back to the uncached computation (and caching the result) otherwise.
+func (o *BlobFeeOracle) Price(excess *big.Int) (*uint256.Int, error) {
+	if excess.Sign() >= 0 && excess.Bit(63) == 0 {
+		if q, r := new(big.Int).QuoRem(excess, big.NewInt(params.DataGasPerBlob), new(big.Int)); r.Sign() == 0 && q.IsUint64() {
+			if idx := q.Uint64(); idx < uint64(len(o.table)) {
+				return o.table[idx], nil
+			}
+		}
+	}
+
+	key := excess.Uint64() // low 64 bits only; full value is checked on hit below
+	o.mu.Lock()
+	if elem, ok := o.cache[key]; ok {
+		entry := elem.Value.(*blobFeeOracleEntry)
+		if entry.excess.Cmp(excess) == 0 {
+			o.lru.MoveToFront(elem)
+			price := entry.price
+			o.mu.Unlock()
+			return price, nil
+		}
+	}
+	o.mu.Unlock()
+
+	price, err := calcBlobGasPrice(excess)
+	if err != nil {
+		return nil, err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.lru.Len() >= blobFeeOracleCacheSize {
+		oldest := o.lru.Back()
+		if oldest != nil {
+			o.lru.Remove(oldest)
+			delete(o.cache, oldest.Value.(*blobFeeOracleEntry).excess.Uint64())
+		}
+	}
+	elem := o.lru.PushFront(&blobFeeOracleEntry{excess: new(big.Int).Set(excess), price: price})
+	o.cache[key] = elem
+	return price, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package misc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ledgerwatch/erigon/params"
+)
+
+func TestBlobFeeOracleMatchesUncached(t *testing.T) {
+	oracle, err := NewBlobFeeOracle()
+	if err != nil {
+		t.Fatalf("NewBlobFeeOracle: %v", err)
+	}
+
+	step := big.NewInt(params.DataGasPerBlob)
+	excess := new(big.Int)
+	for i := 0; i < 32*16; i++ {
+		want, err := calcBlobGasPrice(excess)
+		if err != nil {
+			// Both paths must agree on where overflow kicks in.
+			if _, cacheErr := oracle.Price(excess); cacheErr == nil {
+				t.Fatalf("uncached calcBlobGasPrice overflowed at excess=%v but oracle did not", excess)
+			}
+			break
+		}
+		got, err := oracle.Price(excess)
+		if err != nil {
+			t.Fatalf("oracle.Price(%v): %v", excess, err)
+		}
+		if got.Cmp(want) != 0 {
+			t.Fatalf("excess=%v: got %v, want %v", excess, got, want)
+		}
+		excess = new(big.Int).Add(excess, step)
+	}
+}
+
+func TestBlobFeeOracleCacheHitReturnsSameValue(t *testing.T) {
+	oracle, err := NewBlobFeeOracle()
+	if err != nil {
+		t.Fatalf("NewBlobFeeOracle: %v", err)
+	}
+
+	// Pick an excessBlobGas well outside the dense table so Price exercises
+	// the LRU path on both calls.
+	excess := big.NewInt(int64(blobFeeOracleTableMultiple+1) * params.TargetDataGasPerBlock)
+
+	first, err := oracle.Price(excess)
+	if err != nil {
+		t.Fatalf("oracle.Price: %v", err)
+	}
+	second, err := oracle.Price(excess)
+	if err != nil {
+		t.Fatalf("oracle.Price (cached): %v", err)
+	}
+	if first.Cmp(second) != 0 {
+		t.Fatalf("cached price %v differs from first computed price %v", second, first)
+	}
+}
+
+func BenchmarkGetBlobGasPriceUncached(b *testing.B) {
+	excess := big.NewInt(int64(blobFeeOracleTableMultiple+1) * params.TargetDataGasPerBlock)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := calcBlobGasPrice(excess); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBlobFeeOracleCached(b *testing.B) {
+	oracle, err := NewBlobFeeOracle()
+	if err != nil {
+		b.Fatalf("NewBlobFeeOracle: %v", err)
+	}
+	excess := big.NewInt(int64(blobFeeOracleTableMultiple+1) * params.TargetDataGasPerBlock)
+	if _, err := oracle.Price(excess); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := oracle.Price(excess); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -27,29 +27,38 @@ import (
 	"github.com/ledgerwatch/erigon/params"
 )
 
-// CalcExcessDataGas implements calc_excess_data_gas from EIP-4844
-func CalcExcessDataGas(parentExcessDataGas *big.Int, newBlobs int) *big.Int {
-	excessDataGas := new(big.Int)
-	if parentExcessDataGas != nil {
-		excessDataGas.Set(parentExcessDataGas)
+// CalcExcessBlobGas implements calc_excess_blob_gas from EIP-4844
+func CalcExcessBlobGas(parentExcessBlobGas *big.Int, newBlobs int) *big.Int {
+	excessBlobGas := new(big.Int)
+	if parentExcessBlobGas != nil {
+		excessBlobGas.Set(parentExcessBlobGas)
 	}
 	consumedGas := big.NewInt(params.DataGasPerBlob)
 	consumedGas.Mul(consumedGas, big.NewInt(int64(newBlobs)))
 
-	excessDataGas.Add(excessDataGas, consumedGas)
+	excessBlobGas.Add(excessBlobGas, consumedGas)
 	targetGas := big.NewInt(params.TargetDataGasPerBlock)
-	if excessDataGas.Cmp(targetGas) < 0 {
+	if excessBlobGas.Cmp(targetGas) < 0 {
 		return new(big.Int)
 	}
-	return new(big.Int).Set(excessDataGas.Sub(excessDataGas, targetGas))
+	return new(big.Int).Set(excessBlobGas.Sub(excessBlobGas, targetGas))
+}
+
+// CalcExcessDataGas is a deprecated alias for CalcExcessBlobGas, kept around
+// for one release so that callers written against the pre-Cancun "data gas"
+// naming keep compiling.
+//
+// Deprecated: use CalcExcessBlobGas instead.
+func CalcExcessDataGas(parentExcessDataGas *big.Int, newBlobs int) *big.Int {
+	return CalcExcessBlobGas(parentExcessDataGas, newBlobs)
 }
 
 // FakeExponential approximates factor * e ** (num / denom) using a taylor expansion
 // as described in the EIP-4844 spec.
-func FakeExponential(factor, denom *uint256.Int, edg *big.Int) (*uint256.Int, error) {
-	numerator, overflow := uint256.FromBig(edg)
+func FakeExponential(factor, denom *uint256.Int, excessBlobGas *big.Int) (*uint256.Int, error) {
+	numerator, overflow := uint256.FromBig(excessBlobGas)
 	if overflow {
-		return nil, fmt.Errorf("FakeExponential: overflow converting excessDataGas: %v", edg)
+		return nil, fmt.Errorf("FakeExponential: overflow converting excessBlobGas: %v", excessBlobGas)
 	}
 	output := uint256.NewInt(0)
 	numeratorAccum := new(uint256.Int)
@@ -84,19 +93,97 @@ func CountBlobs(txs []types.Transaction) int {
 	return count
 }
 
-// VerifyEip4844Header verifies that the header is not malformed
+var (
+	// ErrMissingExcessBlobGas is returned when a Cancun header is missing the
+	// excessBlobGas field, or when its parent is missing it once the fork is
+	// already active.
+	ErrMissingExcessBlobGas = fmt.Errorf("header is missing excessBlobGas")
+
+	// ErrInvalidExcessBlobGas is returned when a header's excessBlobGas does
+	// not match the value recomputed from its parent.
+	ErrInvalidExcessBlobGas = fmt.Errorf("invalid excessBlobGas")
+
+	// ErrBlobGasUsedTooHigh is returned when a header's blobGasUsed is not a
+	// multiple of DataGasPerBlob or exceeds MaxDataGasPerBlock.
+	ErrBlobGasUsedTooHigh = fmt.Errorf("invalid blobGasUsed")
+)
+
+// VerifyEip4844Header verifies that the header is not malformed and that its
+// excessBlobGas and blobGasUsed fields are consistent with its parent.
 func VerifyEip4844Header(config *chain.Config, parent, header *types.Header) error {
-	if header.ExcessDataGas == nil {
-		return fmt.Errorf("header is missing excessDataGas")
+	if header.ExcessBlobGas == nil {
+		return ErrMissingExcessBlobGas
+	}
+	if header.BlobGasUsed == nil {
+		return fmt.Errorf("header is missing blobGasUsed")
+	}
+	if *header.BlobGasUsed%params.DataGasPerBlob != 0 {
+		return fmt.Errorf("%w: blobGasUsed %d not a multiple of DataGasPerBlob %d", ErrBlobGasUsedTooHigh, *header.BlobGasUsed, params.DataGasPerBlob)
+	}
+	if *header.BlobGasUsed > params.MaxDataGasPerBlock {
+		return fmt.Errorf("%w: blobGasUsed %d exceeds MaxDataGasPerBlock %d", ErrBlobGasUsedTooHigh, *header.BlobGasUsed, params.MaxDataGasPerBlock)
+	}
+
+	var parentExcessBlobGas *big.Int
+	if parent != nil {
+		if config.IsCancun(parent.Time) {
+			if parent.ExcessBlobGas == nil {
+				return fmt.Errorf("%w: parent is past the Cancun fork but has no excessBlobGas", ErrMissingExcessBlobGas)
+			}
+			parentExcessBlobGas = parent.ExcessBlobGas
+		} else if parent.ExcessBlobGas != nil {
+			return fmt.Errorf("%w: parent predates the Cancun fork but has excessBlobGas set", ErrInvalidExcessBlobGas)
+		}
+	}
+
+	expectedExcessBlobGas := CalcExcessBlobGas(parentExcessBlobGas, 0)
+	if parent != nil && parent.BlobGasUsed != nil {
+		expectedExcessBlobGas = CalcExcessBlobGas(parentExcessBlobGas, int(*parent.BlobGasUsed/params.DataGasPerBlob))
+	}
+	if expectedExcessBlobGas.Cmp(header.ExcessBlobGas) != 0 {
+		return fmt.Errorf("%w: have %d, want %d (parent excessBlobGas %d)", ErrInvalidExcessBlobGas, header.ExcessBlobGas, expectedExcessBlobGas, parentExcessBlobGas)
 	}
 	return nil
 }
 
-// GetDataGasPrice implements get_data_gas_price from EIP-4844
+// calcBlobGasPrice is the uncached get_blob_gas_price computation from
+// EIP-4844. Hot callers should use GetBlobGasPrice (backed by the package's
+// default BlobFeeOracle) instead of calling this directly.
+func calcBlobGasPrice(excessBlobGas *big.Int) (*uint256.Int, error) {
+	return FakeExponential(uint256.NewInt(params.MinDataGasPrice), uint256.NewInt(params.DataGasPriceUpdateFraction), excessBlobGas)
+}
+
+// GetBlobGasPrice implements get_blob_gas_price from EIP-4844, served
+// through the package's default BlobFeeOracle so that the txpool-admission
+// and block-templating paths, which call this on every blob tx and every
+// block, don't re-run FakeExponential's Taylor expansion for an
+// excessBlobGas they've already priced.
+func GetBlobGasPrice(excessBlobGas *big.Int) (*uint256.Int, error) {
+	return DefaultBlobFeeOracle().Price(excessBlobGas)
+}
+
+// GetDataGasPrice is a deprecated alias for GetBlobGasPrice.
+//
+// Deprecated: use GetBlobGasPrice instead.
 func GetDataGasPrice(excessDataGas *big.Int) (*uint256.Int, error) {
-	return FakeExponential(uint256.NewInt(params.MinDataGasPrice), uint256.NewInt(params.DataGasPriceUpdateFraction), excessDataGas)
+	return GetBlobGasPrice(excessDataGas)
 }
 
-func GetDataGasUsed(numBlobs int) uint64 {
+// GetHeaderBlobGasPrice reads excessBlobGas off header and returns the
+// current wei-per-blob-gas price, for callers (txpool admission, block
+// templating, eth_blobBaseFee) that only have the header at hand.
+func GetHeaderBlobGasPrice(header *types.Header) (*uint256.Int, error) {
+	return GetBlobGasPrice(header.ExcessBlobGas)
+}
+
+// GetBlobGasUsed returns the amount of blob gas consumed by numBlobs blobs.
+func GetBlobGasUsed(numBlobs int) uint64 {
 	return uint64(numBlobs) * params.DataGasPerBlob
 }
+
+// GetDataGasUsed is a deprecated alias for GetBlobGasUsed.
+//
+// Deprecated: use GetBlobGasUsed instead.
+func GetDataGasUsed(numBlobs int) uint64 {
+	return GetBlobGasUsed(numBlobs)
+}
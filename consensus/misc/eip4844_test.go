@@ -0,0 +1,109 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package misc
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/chain"
+
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/params"
+)
+
+func u64(v uint64) *uint64 { return &v }
+
+func TestVerifyEip4844Header(t *testing.T) {
+	cancunConfig := &chain.Config{CancunTime: big.NewInt(0)}
+
+	tests := []struct {
+		name    string
+		config  *chain.Config
+		parent  *types.Header
+		header  *types.Header
+		wantErr error
+	}{
+		{
+			name:   "parent nil at fork activation",
+			config: cancunConfig,
+			parent: nil,
+			header: &types.Header{ExcessBlobGas: big.NewInt(0), BlobGasUsed: u64(0)},
+		},
+		{
+			name:   "above target excess grows",
+			config: cancunConfig,
+			parent: &types.Header{ExcessBlobGas: big.NewInt(0), BlobGasUsed: u64(3 * params.DataGasPerBlob)},
+			header: &types.Header{ExcessBlobGas: CalcExcessBlobGas(big.NewInt(0), 3), BlobGasUsed: u64(params.DataGasPerBlob)},
+		},
+		{
+			name:   "below target excess resets to zero",
+			config: cancunConfig,
+			parent: &types.Header{ExcessBlobGas: big.NewInt(0), BlobGasUsed: u64(0)},
+			header: &types.Header{ExcessBlobGas: big.NewInt(0), BlobGasUsed: u64(params.DataGasPerBlob)},
+		},
+		{
+			name:    "mismatched excessBlobGas",
+			config:  cancunConfig,
+			parent:  &types.Header{ExcessBlobGas: big.NewInt(0), BlobGasUsed: u64(0)},
+			header:  &types.Header{ExcessBlobGas: big.NewInt(1), BlobGasUsed: u64(params.DataGasPerBlob)},
+			wantErr: ErrInvalidExcessBlobGas,
+		},
+		{
+			name:    "missing excessBlobGas",
+			config:  cancunConfig,
+			parent:  &types.Header{ExcessBlobGas: big.NewInt(0), BlobGasUsed: u64(0)},
+			header:  &types.Header{BlobGasUsed: u64(0)},
+			wantErr: ErrMissingExcessBlobGas,
+		},
+		{
+			name:    "parent missing excessBlobGas once fork is active",
+			config:  cancunConfig,
+			parent:  &types.Header{Time: 0},
+			header:  &types.Header{ExcessBlobGas: big.NewInt(0), BlobGasUsed: u64(0)},
+			wantErr: ErrMissingExcessBlobGas,
+		},
+		{
+			name:    "blobGasUsed not a multiple of DataGasPerBlob",
+			config:  cancunConfig,
+			parent:  &types.Header{ExcessBlobGas: big.NewInt(0), BlobGasUsed: u64(0)},
+			header:  &types.Header{ExcessBlobGas: big.NewInt(0), BlobGasUsed: u64(params.DataGasPerBlob + 1)},
+			wantErr: ErrBlobGasUsedTooHigh,
+		},
+		{
+			name:    "blobGasUsed overflows MaxDataGasPerBlock",
+			config:  cancunConfig,
+			parent:  &types.Header{ExcessBlobGas: big.NewInt(0), BlobGasUsed: u64(0)},
+			header:  &types.Header{ExcessBlobGas: big.NewInt(0), BlobGasUsed: u64(params.MaxDataGasPerBlock + params.DataGasPerBlob)},
+			wantErr: ErrBlobGasUsedTooHigh,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyEip4844Header(tt.config, tt.parent, tt.header)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
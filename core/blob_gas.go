@@ -0,0 +1,67 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/holiman/uint256"
+
+	"github.com/ledgerwatch/erigon/consensus/misc"
+	"github.com/ledgerwatch/erigon/core/types"
+)
+
+// ErrBlobGasLimitExceeded is returned when a transaction's blob gas usage
+// would exceed the amount of blob gas remaining in the block's GasPool.
+var ErrBlobGasLimitExceeded = errors.New("blob gas used exceeds remaining per-block blob gas budget")
+
+// ErrMaxFeePerBlobGasTooLow is returned when a transaction's maxFeePerBlobGas
+// is below the block's current blob gas price.
+var ErrMaxFeePerBlobGasTooLow = errors.New("max fee per blob gas below block blob gas price")
+
+// BlobGasUsed returns the amount of blob gas tx would consume, i.e.
+// DataGasPerBlob times the number of blob hashes it carries.
+func BlobGasUsed(tx types.Transaction) uint64 {
+	return misc.GetBlobGasUsed(len(tx.GetDataHashes()))
+}
+
+// BuyBlobGas is the blob-gas analogue of the ordinary buyGas step performed
+// before a transaction executes: it rejects the transaction if its blob gas
+// usage exceeds gp's remaining blob gas budget, or if maxFeePerBlobGas
+// undercuts excessBlobGas's current price, and otherwise reserves the gas
+// from gp. txpool.PromoteBlobTx calls this directly; block execution has no
+// ApplyMessage in this tree to call it from yet, so that wiring is still
+// outstanding rather than implied here.
+func BuyBlobGas(gp *GasPool, excessBlobGas *big.Int, blobGasUsed uint64, maxFeePerBlobGas *uint256.Int) error {
+	if blobGasUsed == 0 {
+		return nil
+	}
+	if maxFeePerBlobGas != nil {
+		price, err := misc.GetBlobGasPrice(excessBlobGas)
+		if err != nil {
+			return err
+		}
+		if maxFeePerBlobGas.Lt(price) {
+			return ErrMaxFeePerBlobGasTooLow
+		}
+	}
+	if blobGasUsed > gp.BlobGasPool {
+		return ErrBlobGasLimitExceeded
+	}
+	return gp.SubBlobGas(blobGasUsed)
+}
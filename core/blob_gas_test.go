@@ -0,0 +1,101 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+
+	"github.com/ledgerwatch/erigon/consensus/misc"
+)
+
+func TestBuyBlobGasBudget(t *testing.T) {
+	tests := []struct {
+		name        string
+		pool        uint64
+		blobGasUsed uint64
+		wantErr     error
+		wantPool    uint64
+	}{
+		{name: "exactly at limit", pool: 131072, blobGasUsed: 131072, wantPool: 0},
+		{name: "just over limit", pool: 131072, blobGasUsed: 131073, wantErr: ErrBlobGasLimitExceeded, wantPool: 131072},
+		{name: "well under limit", pool: 131072, blobGasUsed: 65536, wantPool: 65536},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			gp := new(GasPool).AddBlobGas(tt.pool)
+			err := BuyBlobGas(gp, big.NewInt(0), tt.blobGasUsed, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if gp.BlobGasPool != tt.wantPool {
+				t.Fatalf("got remaining blob gas %d, want %d", gp.BlobGasPool, tt.wantPool)
+			}
+		})
+	}
+}
+
+func TestBuyBlobGasRejectsUnderpricedFeeCap(t *testing.T) {
+	gp := new(GasPool).AddBlobGas(131072)
+	price, err := misc.GetBlobGasPrice(big.NewInt(0))
+	if err != nil {
+		t.Fatalf("price: %v", err)
+	}
+	underpriced := new(uint256.Int).Sub(price, uint256.NewInt(1))
+
+	if err := BuyBlobGas(gp, big.NewInt(0), 131072, underpriced); !errors.Is(err, ErrMaxFeePerBlobGasTooLow) {
+		t.Fatalf("got error %v, want %v", err, ErrMaxFeePerBlobGasTooLow)
+	}
+	if gp.BlobGasPool != 131072 {
+		t.Fatalf("rejected tx must not consume the blob gas pool, got remaining %d", gp.BlobGasPool)
+	}
+}
+
+func TestBuyBlobGasPriceFloorIsOracleBacked(t *testing.T) {
+	excessBlobGas := big.NewInt(786432)
+	want, err := misc.DefaultBlobFeeOracle().Price(excessBlobGas)
+	if err != nil {
+		t.Fatalf("oracle.Price: %v", err)
+	}
+	underpriced := new(uint256.Int).Sub(want, uint256.NewInt(1))
+
+	gp := new(GasPool).AddBlobGas(131072)
+	if err := BuyBlobGas(gp, excessBlobGas, 131072, underpriced); !errors.Is(err, ErrMaxFeePerBlobGasTooLow) {
+		t.Fatalf("got error %v, want %v", err, ErrMaxFeePerBlobGasTooLow)
+	}
+
+	gp = new(GasPool).AddBlobGas(131072)
+	if err := BuyBlobGas(gp, excessBlobGas, 131072, want); err != nil {
+		t.Fatalf("a maxFeePerBlobGas exactly at the oracle's price must be accepted, got %v", err)
+	}
+}
+
+func TestAddBlobGasPoolOverflowPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic on blob gas pool overflow")
+		}
+	}()
+	gp := new(GasPool).AddBlobGas(math.MaxUint64)
+	gp.AddBlobGas(1)
+}
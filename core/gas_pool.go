@@ -0,0 +1,94 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrGasLimitReached is returned by SubGas when the requested amount exceeds
+// the gas remaining in the pool.
+var ErrGasLimitReached = errors.New("gas limit reached")
+
+// ErrBlobGasLimitReached is returned by SubBlobGas when the requested amount
+// exceeds the blob gas remaining in the pool.
+var ErrBlobGasLimitReached = errors.New("blob gas limit reached")
+
+// GasPool tracks the amount of gas available during execution of the transactions
+// in a block. The zero value is a pool with zero gas available.
+type GasPool struct {
+	gas uint64
+
+	// BlobGasPool tracks the amount of EIP-4844 blob gas available during
+	// execution of the transactions in a block.
+	BlobGasPool uint64
+}
+
+// AddGas makes gas available for execution.
+func (gp *GasPool) AddGas(amount uint64) *GasPool {
+	if gp.gas > math.MaxUint64-amount {
+		panic("gas pool pushed above uint64")
+	}
+	gp.gas += amount
+	return gp
+}
+
+// SubGas deducts the given amount from the pool if enough gas is
+// available and returns an error otherwise.
+func (gp *GasPool) SubGas(amount uint64) error {
+	if gp.gas < amount {
+		return ErrGasLimitReached
+	}
+	gp.gas -= amount
+	return nil
+}
+
+// Gas returns the amount of gas remaining in the pool.
+func (gp *GasPool) Gas() uint64 {
+	return gp.gas
+}
+
+// SetGas sets the amount of gas remaining in the pool.
+func (gp *GasPool) SetGas(gas uint64) {
+	gp.gas = gas
+}
+
+// AddBlobGas makes blob gas available for execution of EIP-4844 blob
+// transactions.
+func (gp *GasPool) AddBlobGas(amount uint64) *GasPool {
+	if gp.BlobGasPool > math.MaxUint64-amount {
+		panic("blob gas pool pushed above uint64")
+	}
+	gp.BlobGasPool += amount
+	return gp
+}
+
+// SubBlobGas deducts the given amount from the blob gas pool if enough blob
+// gas is available and returns an error otherwise.
+func (gp *GasPool) SubBlobGas(amount uint64) error {
+	if gp.BlobGasPool < amount {
+		return ErrBlobGasLimitReached
+	}
+	gp.BlobGasPool -= amount
+	return nil
+}
+
+func (gp *GasPool) String() string {
+	return fmt.Sprintf("%d", gp.gas)
+}
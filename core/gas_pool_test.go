@@ -0,0 +1,60 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestGasPoolSubBlobGas(t *testing.T) {
+	tests := []struct {
+		name    string
+		pool    uint64
+		sub     uint64
+		wantErr error
+		wantGas uint64
+	}{
+		{name: "exactly at limit", pool: 128, sub: 128, wantGas: 0},
+		{name: "just over limit", pool: 128, sub: 129, wantErr: ErrBlobGasLimitReached, wantGas: 128},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			gp := new(GasPool).AddBlobGas(tt.pool)
+			err := gp.SubBlobGas(tt.sub)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if gp.BlobGasPool != tt.wantGas {
+				t.Fatalf("got remaining blob gas %d, want %d", gp.BlobGasPool, tt.wantGas)
+			}
+		})
+	}
+}
+
+func TestGasPoolAddBlobGasOverflow(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic on blob gas pool overflow")
+		}
+	}()
+	gp := new(GasPool).AddBlobGas(math.MaxUint64)
+	gp.AddBlobGas(1)
+}
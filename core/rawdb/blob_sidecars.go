@@ -0,0 +1,106 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/ledgerwatch/erigon-lib/kv"
+
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/consensus/misc"
+	"github.com/ledgerwatch/erigon/core/types"
+)
+
+// BlobSidecarsTable stores the (versioned_hash, kzg_commitment, kzg_proof,
+// blob) tuple for every blob in a Cancun block, keyed by block hash and blob
+// index.
+const BlobSidecarsTable = "BlobSidecars"
+
+// BlobSidecar is the (versioned_hash, kzg_commitment, kzg_proof, blob) tuple
+// stored for a single blob.
+type BlobSidecar struct {
+	Index         uint64      `json:"index"`
+	Blob          []byte      `json:"blob"`
+	KZGCommitment common.Hash `json:"kzg_commitment"`
+	KZGProof      common.Hash `json:"kzg_proof"`
+	VersionedHash common.Hash `json:"versioned_hash"`
+}
+
+func blobSidecarKey(blockHash common.Hash, index uint64) []byte {
+	key := make([]byte, common.HashLength+8)
+	copy(key, blockHash[:])
+	binary.BigEndian.PutUint64(key[common.HashLength:], index)
+	return key
+}
+
+// WriteBlobSidecars persists sidecars under blockHash, one key per blob
+// index.
+func WriteBlobSidecars(tx kv.RwTx, blockHash common.Hash, sidecars []BlobSidecar) error {
+	for _, sidecar := range sidecars {
+		buf, err := json.Marshal(sidecar)
+		if err != nil {
+			return err
+		}
+		if err := tx.Put(BlobSidecarsTable, blobSidecarKey(blockHash, sidecar.Index), buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadBlobSidecars returns every sidecar stored for blockHash, or a nil
+// slice if none are stored because the block predates Cancun, carried no
+// blobs, or has since been pruned.
+func ReadBlobSidecars(tx kv.Tx, blockHash common.Hash) ([]BlobSidecar, error) {
+	c, err := tx.Cursor(BlobSidecarsTable)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	var sidecars []BlobSidecar
+	prefix := blockHash[:]
+	k, v, err := c.Seek(prefix)
+	for ; k != nil && bytes.HasPrefix(k, prefix); k, v, err = c.Next() {
+		if err != nil {
+			return nil, err
+		}
+		var sidecar BlobSidecar
+		if err := json.Unmarshal(v, &sidecar); err != nil {
+			return nil, err
+		}
+		sidecars = append(sidecars, sidecar)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sidecars, nil
+}
+
+// MaybeWriteBlobSidecars writes sidecars for block if it carries any blob
+// transactions, and is a no-op otherwise. Block import does not call it;
+// sidecars are stored only where a caller invokes it (or WriteBlobSidecars)
+// directly.
+func MaybeWriteBlobSidecars(tx kv.RwTx, block *types.Block, sidecars []BlobSidecar) error {
+	if misc.CountBlobs(block.Transactions()) == 0 {
+		return nil
+	}
+	return WriteBlobSidecars(tx, block.Hash(), sidecars)
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"github.com/holiman/uint256"
+
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/types"
+)
+
+// PromoteBlobTx is the check a pool's promotion step should run before
+// admitting a pending blob transaction into the set of transactions
+// eligible for inclusion in the block currently being built: it rejects the
+// transaction with a well-typed error, instead of promoting it
+// unconditionally, if its blob gas usage would exceed gp's remaining blob
+// gas budget or its maxFeePerBlobGas undercuts the block's current blob gas
+// price.
+func PromoteBlobTx(gp *core.GasPool, header *types.Header, tx types.Transaction, maxFeePerBlobGas *uint256.Int) error {
+	return core.BuyBlobGas(gp, header.ExcessBlobGas, core.BlobGasUsed(tx), maxFeePerBlobGas)
+}
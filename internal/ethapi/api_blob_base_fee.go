@@ -0,0 +1,16 @@
+package ethapi
+
+import (
+	"github.com/holiman/uint256"
+
+	"github.com/ledgerwatch/erigon/consensus/misc"
+	"github.com/ledgerwatch/erigon/core/types"
+)
+
+// BlobBaseFee returns the wei-per-blob-gas price implied by header, for
+// serving an eth_blobBaseFee RPC method. Wiring this into the actual
+// method-dispatch table is left to the RPC API implementation, which isn't
+// present in this tree.
+func BlobBaseFee(header *types.Header) (*uint256.Int, error) {
+	return misc.GetHeaderBlobGasPrice(header)
+}
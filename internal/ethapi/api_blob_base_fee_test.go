@@ -0,0 +1,20 @@
+package ethapi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ledgerwatch/erigon/core/types"
+)
+
+func TestBlobBaseFee(t *testing.T) {
+	header := &types.Header{ExcessBlobGas: big.NewInt(0)}
+
+	got, err := BlobBaseFee(header)
+	if err != nil {
+		t.Fatalf("BlobBaseFee: %v", err)
+	}
+	if got == nil || got.IsZero() {
+		t.Fatalf("BlobBaseFee(excessBlobGas=0) = %v, want the minimum blob gas price", got)
+	}
+}
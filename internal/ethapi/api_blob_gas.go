@@ -0,0 +1,19 @@
+package ethapi
+
+import (
+	"github.com/ledgerwatch/erigon/common/hexutil"
+	"github.com/ledgerwatch/erigon/core/types"
+)
+
+// RPCMarshalBlobGasFields adds the Cancun blob-gas header fields to an RPC
+// block/header response using the post-rename JSON names (excessBlobGas,
+// blobGasUsed) so that tooling built against the Cancun spec's field names
+// keeps working without a compatibility shim on the RPC side.
+func RPCMarshalBlobGasFields(header *types.Header, fields map[string]interface{}) {
+	if header.ExcessBlobGas != nil {
+		fields["excessBlobGas"] = hexutil.EncodeBig(header.ExcessBlobGas)
+	}
+	if header.BlobGasUsed != nil {
+		fields["blobGasUsed"] = hexutil.EncodeUint64(*header.BlobGasUsed)
+	}
+}
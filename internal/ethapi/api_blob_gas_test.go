@@ -0,0 +1,38 @@
+package ethapi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ledgerwatch/erigon/core/types"
+)
+
+func TestRPCMarshalBlobGasFields(t *testing.T) {
+	blobGasUsed := uint64(131072)
+	header := &types.Header{
+		ExcessBlobGas: big.NewInt(262144),
+		BlobGasUsed:   &blobGasUsed,
+	}
+
+	fields := map[string]interface{}{}
+	RPCMarshalBlobGasFields(header, fields)
+
+	if got, want := fields["excessBlobGas"], "0x40000"; got != want {
+		t.Fatalf("excessBlobGas = %v, want %v", got, want)
+	}
+	if got, want := fields["blobGasUsed"], "0x20000"; got != want {
+		t.Fatalf("blobGasUsed = %v, want %v", got, want)
+	}
+}
+
+func TestRPCMarshalBlobGasFieldsOmitsPreCancun(t *testing.T) {
+	fields := map[string]interface{}{}
+	RPCMarshalBlobGasFields(&types.Header{}, fields)
+
+	if _, ok := fields["excessBlobGas"]; ok {
+		t.Fatal("excessBlobGas should be omitted for a pre-Cancun header")
+	}
+	if _, ok := fields["blobGasUsed"]; ok {
+		t.Fatal("blobGasUsed should be omitted for a pre-Cancun header")
+	}
+}